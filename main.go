@@ -1,3 +1,4 @@
+//The build tag the install needs is in data/README.md
 package main
 
 import (
@@ -18,6 +19,7 @@ import (
 
 var (
 	installFlag = flag.Bool("install", false, "install to a local db")
+	updateFlag  = flag.Bool("update", false, "update an installed db with a newer JMdict file")
 	configFlag  = flag.String("config", "config.json", "load a custom config file")
 	config      = &configuration{}
 )
@@ -75,6 +77,25 @@ func main() {
 			logger.Fatal(err)
 		}
 
+		logger.Info("Installing RADKFILE/KRADFILE...")
+		err = install.Components(config.Install)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		os.Exit(0)
+	}
+
+	//Check if were updating an existing install
+	if *updateFlag {
+		logger.Info("Updating JMDict...")
+		summary, err := install.UpdateJMDict(config.Install)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Info("JMDict updated: ", summary)
+
 		os.Exit(0)
 	}
 
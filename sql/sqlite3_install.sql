@@ -20,10 +20,13 @@ DROP TABLE IF EXISTS stagr;
 DROP TABLE IF EXISTS xref;
 DROP TABLE IF EXISTS sens;
 DROP TABLE IF EXISTS enty;
+DROP TABLE IF EXISTS entity;
 
+/*hash is a fingerprint of the whole <entry>, used by --update*/
 CREATE TABLE enty (
   id INTEGER PRIMARY KEY AUTOINCREMENT,
-  entseq INTEGER UNIQUE
+  entseq INTEGER UNIQUE,
+  hash TEXT
 );
 
 CREATE TABLE kanj (
@@ -46,11 +49,13 @@ CREATE TABLE kpri (
   PRIMARY KEY (kid,kw)
 );
 
+/*rnorm is rval in hiragana (see kana.Normalize) for script insensitive lookups*/
 CREATE TABLE rdng (
   id INTEGER PRIMARY KEY AUTOINCREMENT,
   rval TEXT,
+  rnorm TEXT,
   eid INTEGER REFERENCES enty (id),
-  nokj TEXT
+  nokj INTEGER(1) DEFAULT '0'
 );
 
 CREATE TABLE audio (
@@ -93,10 +98,14 @@ CREATE TABLE field (
   PRIMARY KEY (sid,ctg)
 );
 
+/*antonym, entseq is the resolved target (NULL when it could not be found)*/
 CREATE TABLE ant (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
   sid INTEGER REFERENCES sens (id),
-  rdng TEXT,
-  PRIMARY KEY (sid,rdng)
+  keb TEXT,
+  reb TEXT,
+  snum INTEGER,
+  entseq INTEGER
 );
 
 CREATE TABLE misc (
@@ -153,10 +162,21 @@ CREATE TABLE stagr (
   PRIMARY KEY (sid,rdng)
 );
 
+/*cross-reference, entseq is the resolved target (NULL when it could not be found)*/
 CREATE TABLE xref (
   id INTEGER PRIMARY KEY AUTOINCREMENT,
   sid INTEGER REFERENCES sens (id),
-  rdng TEXT
+  keb TEXT,
+  reb TEXT,
+  snum INTEGER,
+  entseq INTEGER
+);
+
+/*<!ENTITY> codes from the DOCTYPE (v5k, adj-i...) and the text pos, misc,
+  field etc. store in their place*/
+CREATE TABLE entity (
+  name TEXT PRIMARY KEY,
+  descr TEXT
 );
 
 CREATE INDEX enty_entseq_idx ON enty(entseq);
@@ -171,6 +191,8 @@ CREATE INDEX rdng_id_idx ON rdng(id);
 
 CREATE INDEX rdng_rval_idx ON rdng(rval);
 
+CREATE INDEX rdng_rnorm_idx ON rdng(rnorm);
+
 CREATE INDEX rdng_eid_idx ON rdng(eid);
 
 CREATE INDEX sens_id_idx ON sens(id);
@@ -183,105 +205,166 @@ CREATE INDEX gloss_sid_idx ON gloss(sid);
 
 CREATE INDEX pos_sid_idx ON pos(sid);
 
+CREATE INDEX entity_descr_idx ON entity(descr);
+
+CREATE INDEX xref_sid_idx ON xref(sid);
+
+CREATE INDEX ant_sid_idx ON ant(sid);
+
+/*full-text index of each sense, one row per gloss language. Filled by the
+  installer once every entry is in. FTS5 build: see data/README.md*/
+DROP TABLE IF EXISTS sensefts;
+CREATE VIRTUAL TABLE sensefts USING fts5 (
+  sid UNINDEXED,
+  lang UNINDEXED,
+  gloss,
+  sinf,
+  lsource,
+  tokenize = 'unicode61 remove_diacritics 2'
+);
+
 /* KanjiDic2 */
 DROP TABLE IF EXISTS codepoint;
 DROP TABLE IF EXISTS dictextrainfo;
 DROP TABLE IF EXISTS kdictionary;
 DROP TABLE IF EXISTS meaning;
 DROP TABLE IF EXISTS naori;
+DROP TABLE IF EXISTS nanori;
 DROP TABLE IF EXISTS querycode;
 DROP TABLE IF EXISTS radical;
 DROP TABLE IF EXISTS radicalName;
+DROP TABLE IF EXISTS radname;
 DROP TABLE IF EXISTS reading;
 DROP TABLE IF EXISTS strokecount;
 DROP TABLE IF EXISTS variant;
 DROP TABLE IF EXISTS kcharacter;
 
--- CREATE TABLE kcharacter (
---   id INTEGER PRIMARY KEY AUTOINCREMENT,
---   literal VARCHAR NOT NULL UNIQUE,
---   grade INTEGER,
---   frequency INTEGER,
---   jlpt INTEGER
--- );
---
--- CREATE TABLE codepoint (
---   cid INTEGER REFERENCES kcharacter (id),
---   type VARCHAR,
---   value VARCHAR,
---   PRIMARY KEY (cid,type,value)
--- );
---
--- CREATE TABLE kdictionary (
---   cid INTEGER REFERENCES kcharacter (id),
---   dicindex VARCHAR,
---   name VARCHAR,
---   PRIMARY KEY (cid,dicindex,name)
--- );
---
--- CREATE TABLE dictextrainfo (
---   dict VARCHAR REFERENCES kdictionary (name),
---   volume VARCHAR,
---   page VARCHAR
--- );
---
--- CREATE TABLE meaning (
---   cid INTEGER REFERENCES kcharacter (id),
---   value VARCHAR,
---   lang VARCHAR,
---   insertionOrder INTEGER,
---   PRIMARY KEY (cid,value,lang)
--- );
---
--- CREATE TABLE naori (
---   cid INTEGER REFERENCES kcharacter (id),
---   value VARCHAR,
---   PRIMARY KEY (cid,value)
--- );
---
--- CREATE TABLE querycode (
---   cid INTEGER REFERENCES kcharacter (id),
---   code VARCHAR,
---   type VARCHAR,
---   misclass VARCHAR,
---   PRIMARY KEY (cid,code,type)
--- );
---
--- CREATE TABLE radical (
---   cid INTEGER REFERENCES kcharacter (id),
---   type VARCHAR,
---   value VARCHAR,
---   PRIMARY KEY (cid,type,value)
--- );
---
--- CREATE TABLE radicalName (
---   cid INTEGER REFERENCES kcharacter (id),
---   name VARCHAR
--- );
---
--- CREATE TABLE reading (
---   cid INTEGER REFERENCES kcharacter (id),
---   value VARCHAR,
---   type VARCHAR,
---   status VARCHAR,
---   ontype VARCHAR,
---   PRIMARY KEY (cid,value,type)
--- );
---
--- CREATE TABLE strokecount (
---   cid INTEGER REFERENCES kcharacter (id),
---   count INTEGER,
---   "default" INTEGER
--- );
---
--- CREATE TABLE variant (
---   cid INTEGER REFERENCES kcharacter (id),
---   type VARCHAR,
---   value CHAR,
---   PRIMARY KEY (cid,type,value)
--- );
---
--- CREATE INDEX kcharacter_literal_idx ON kcharacter(literal);
+CREATE TABLE kcharacter (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  literal TEXT NOT NULL UNIQUE,
+  grade INTEGER,
+  frequency INTEGER,
+  jlpt INTEGER
+);
+
+/*ucs, jis208, jis212, jis213*/
+CREATE TABLE codepoint (
+  cid INTEGER REFERENCES kcharacter (id),
+  type TEXT,
+  value TEXT,
+  PRIMARY KEY (cid,type,value)
+);
+
+/*classical or nelson_c*/
+CREATE TABLE radical (
+  cid INTEGER REFERENCES kcharacter (id),
+  type TEXT,
+  value INTEGER,
+  PRIMARY KEY (cid,type)
+);
+
+/*the first stroke count is the accepted one, the rest are common miscounts*/
+CREATE TABLE strokecount (
+  cid INTEGER REFERENCES kcharacter (id),
+  count INTEGER,
+  accepted INTEGER(1) DEFAULT '0',
+  PRIMARY KEY (cid,count)
+);
+
+CREATE TABLE variant (
+  cid INTEGER REFERENCES kcharacter (id),
+  type TEXT,
+  value TEXT,
+  PRIMARY KEY (cid,type,value)
+);
+
+/*name of the kanji when it is itself a radical*/
+CREATE TABLE radname (
+  cid INTEGER REFERENCES kcharacter (id),
+  name TEXT,
+  PRIMARY KEY (cid,name)
+);
+
+/*dic_ref, volume and page are only used by moro*/
+CREATE TABLE kdictionary (
+  cid INTEGER REFERENCES kcharacter (id),
+  type TEXT,
+  dicindex TEXT,
+  volume TEXT,
+  page TEXT,
+  PRIMARY KEY (cid,type,dicindex)
+);
+
+/*skip, sh_desc, four_corner, deroo, misclass*/
+CREATE TABLE querycode (
+  cid INTEGER REFERENCES kcharacter (id),
+  type TEXT,
+  code TEXT,
+  misclass TEXT,
+  PRIMARY KEY (cid,type,code)
+);
+
+/*pinyin, korean_r, korean_h, ja_on, ja_kun*/
+CREATE TABLE reading (
+  cid INTEGER REFERENCES kcharacter (id),
+  type TEXT,
+  value TEXT,
+  status TEXT,
+  ontype TEXT,
+  PRIMARY KEY (cid,type,value)
+);
+
+CREATE TABLE meaning (
+  cid INTEGER REFERENCES kcharacter (id),
+  value TEXT,
+  lang TEXT DEFAULT 'en',
+  ord INTEGER,
+  PRIMARY KEY (cid,lang,value)
+);
+
+CREATE TABLE nanori (
+  cid INTEGER REFERENCES kcharacter (id),
+  value TEXT,
+  PRIMARY KEY (cid,value)
+);
+
+CREATE INDEX kcharacter_literal_idx ON kcharacter(literal);
+
+CREATE INDEX codepoint_cid_idx ON codepoint(cid);
+
+CREATE INDEX radical_cid_idx ON radical(cid);
+
+CREATE INDEX strokecount_cid_idx ON strokecount(cid);
+
+CREATE INDEX kdictionary_cid_idx ON kdictionary(cid);
+
+CREATE INDEX kdictionary_type_idx ON kdictionary(type, dicindex);
+
+CREATE INDEX querycode_cid_idx ON querycode(cid);
+
+CREATE INDEX reading_cid_idx ON reading(cid);
+
+CREATE INDEX meaning_cid_idx ON meaning(cid);
+
+/* KRADFILE/RADKFILE */
+DROP TABLE IF EXISTS kcomponent;
+DROP TABLE IF EXISTS component;
+
+/*a component kanji are picked by, strokes is its own stroke count*/
+CREATE TABLE component (
+  literal TEXT PRIMARY KEY,
+  strokes INTEGER
+);
+
+/*the components a kanji is made of. Kept by literal, not kcharacter (id),
+  as KanjiDic2 is installed separately*/
+CREATE TABLE kcomponent (
+  kanji TEXT,
+  component TEXT REFERENCES component (literal),
+  PRIMARY KEY (kanji,component)
+);
+
+CREATE INDEX kcomponent_component_idx ON kcomponent(component);
 
 
 /* VIEWS */